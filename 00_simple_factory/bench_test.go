@@ -0,0 +1,27 @@
+package simplefactory
+
+import "testing"
+
+// legacyNewAPI是重构前的if/else标签分发写法，只用来和基于Factory的NewAPI做基准对比
+func legacyNewAPI(t int) API {
+	if t == 1 {
+		return &hiAPI{}
+	} else if t == 2 {
+		return &hiAPI{}
+	}
+	return nil
+}
+
+// BenchmarkNewAPI 基准测试基于Factory[int, API]的NewAPI
+func BenchmarkNewAPI(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewAPI(1)
+	}
+}
+
+// BenchmarkLegacyNewAPI 基准测试重构前if/else标签分发的实现，作为对比基线
+func BenchmarkLegacyNewAPI(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		legacyNewAPI(1)
+	}
+}
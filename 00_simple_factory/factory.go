@@ -0,0 +1,43 @@
+package simplefactory
+
+import "fmt"
+
+// Factory 是一个按key分发构造函数的通用简单工厂
+// K是注册用的key类型，T是工厂产出的产品类型
+// 相比int标签+if/else的写法，新增一种产品只需要Register一次，
+// 调用方也不需要修改工厂内部代码就能扩展它
+type Factory[K comparable, T any] struct {
+	ctors map[K]func() T
+}
+
+// NewFactory 创建一个空的Factory
+func NewFactory[K comparable, T any]() *Factory[K, T] {
+	return &Factory[K, T]{ctors: make(map[K]func() T)}
+}
+
+// Register 为key注册一个构造函数，key重复会返回error
+func (f *Factory[K, T]) Register(key K, ctor func() T) error {
+	if _, exists := f.ctors[key]; exists {
+		return fmt.Errorf("simplefactory: key %v is already registered", key)
+	}
+	f.ctors[key] = ctor
+	return nil
+}
+
+// MustRegister和Register一样，但注册失败时直接panic
+// 适合在init()里为固定的key集合注册构造函数
+func (f *Factory[K, T]) MustRegister(key K, ctor func() T) {
+	if err := f.Register(key, ctor); err != nil {
+		panic(err)
+	}
+}
+
+// New按key查找并调用对应的构造函数创建一个T
+func (f *Factory[K, T]) New(key K) (T, error) {
+	ctor, ok := f.ctors[key]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("simplefactory: no constructor registered for key %v", key)
+	}
+	return ctor(), nil
+}
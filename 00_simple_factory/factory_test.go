@@ -0,0 +1,62 @@
+package simplefactory
+
+import "testing"
+
+// TestFactoryRegisterAndNew 验证Register之后可以通过New按key拿到对应的实例
+func TestFactoryRegisterAndNew(t *testing.T) {
+	f := NewFactory[string, int]()
+	if err := f.Register("one", func() int { return 1 }); err != nil {
+		t.Fatalf("Register失败: %v", err)
+	}
+
+	got, err := f.New("one")
+	if err != nil {
+		t.Fatalf("New失败: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("New(\"one\") = %d, 期望 1", got)
+	}
+}
+
+// TestFactoryDuplicateKey 验证重复注册同一个key会返回error而不是覆盖
+func TestFactoryDuplicateKey(t *testing.T) {
+	f := NewFactory[int, string]()
+	if err := f.Register(1, func() string { return "a" }); err != nil {
+		t.Fatalf("首次Register失败: %v", err)
+	}
+	if err := f.Register(1, func() string { return "b" }); err == nil {
+		t.Fatal("期望重复key的Register返回error")
+	}
+}
+
+// TestFactoryMustRegisterPanics 验证MustRegister在key重复时会panic
+func TestFactoryMustRegisterPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("期望MustRegister在key重复时panic")
+		}
+	}()
+
+	f := NewFactory[int, string]()
+	f.MustRegister(1, func() string { return "a" })
+	f.MustRegister(1, func() string { return "b" })
+}
+
+// TestFactoryUnknownKey 验证New查找未注册的key会返回error而不是panic
+func TestFactoryUnknownKey(t *testing.T) {
+	f := NewFactory[int, string]()
+	if _, err := f.New(1); err == nil {
+		t.Fatal("期望查找未注册的key返回error")
+	}
+}
+
+// TestRegisterAPICustomTag 验证调用方可以不修改NewAPI就扩展新的标签
+func TestRegisterAPICustomTag(t *testing.T) {
+	const customTag = 99
+	if err := RegisterAPI(customTag, func() API { return &hiAPI{} }); err != nil {
+		t.Fatalf("RegisterAPI失败: %v", err)
+	}
+	if NewAPI(customTag).Say("Tom") != "Hi, Tom" {
+		t.Error("自定义标签创建的API行为不符合预期")
+	}
+}
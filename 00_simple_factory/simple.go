@@ -0,0 +1,39 @@
+package simplefactory
+
+import "fmt"
+
+// API 是simple factory创建的产品接口
+type API interface {
+	Say(name string) string
+}
+
+// hiAPI 是API的一个具体实现，返回形如"Hi, Tom"的问候语
+type hiAPI struct{}
+
+func (*hiAPI) Say(name string) string {
+	return fmt.Sprintf("Hi, %s", name)
+}
+
+// apiFactory 是NewAPI依赖的Factory[int, API]实例，两个标签都在init()里注册好
+var apiFactory = NewFactory[int, API]()
+
+func init() {
+	apiFactory.MustRegister(1, func() API { return &hiAPI{} })
+	apiFactory.MustRegister(2, func() API { return &hiAPI{} })
+}
+
+// NewAPI 按t选择并创建一个API实现
+// 基于Factory[int, API]实现，替换掉原来的if t == 1 ... else if t == 2写法
+func NewAPI(t int) API {
+	api, err := apiFactory.New(t)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+// RegisterAPI 让调用方在包外为新的标签注册自己的API实现，无需修改NewAPI，
+// tag重复会返回error
+func RegisterAPI(tag int, ctor func() API) error {
+	return apiFactory.Register(tag, ctor)
+}
@@ -1,16 +1,27 @@
 package abstractfactory
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
 	"golang-design-pattern/05_abstract_factory/factories"
 	"golang-design-pattern/05_abstract_factory/interfaces"
-	"testing"
 )
 
+// errFakeSaveDetail 用于在测试中模拟工作单元中途失败的场景
+var errFakeSaveDetail = errors.New("save detail failed")
+
 // getMainAndDetail 客户端函数：使用抽象工厂创建并使用DAO对象
 // 该函数只依赖于抽象工厂和抽象产品接口，不依赖于具体实现
-func getMainAndDetail(factory interfaces.DAOFactory) {
-	factory.CreateOrderMainDAO().SaveOrderMain()
-	factory.CreateOrderDetailDAO().SaveOrderDetail()
+func getMainAndDetail(ctx context.Context, factory interfaces.DAOFactory, main interfaces.OrderMain, detail interfaces.OrderDetail) error {
+	if err := factory.CreateOrderMainDAO().SaveOrderMain(ctx, main); err != nil {
+		return err
+	}
+	return factory.CreateOrderDetailDAO().SaveOrderDetail(ctx, detail)
 }
 
 // ExampleRDBDAOFactory 测试RDB工厂的使用示例
@@ -18,10 +29,12 @@ func getMainAndDetail(factory interfaces.DAOFactory) {
 func ExampleRDBDAOFactory() {
 	var factory interfaces.DAOFactory
 	factory = &factories.RDBDAOFactory{}
-	getMainAndDetail(factory)
+	_ = getMainAndDetail(context.Background(), factory,
+		interfaces.OrderMain{ID: "order-1"},
+		interfaces.OrderDetail{ID: "detail-1", OrderID: "order-1"})
 	// Output:
-	// rdb main save
-	// rdb detail save
+	// rdb main save: order-1
+	// rdb detail save: detail-1
 }
 
 // ExampleXMLDAOFactory 测试XML工厂的使用示例
@@ -29,10 +42,12 @@ func ExampleRDBDAOFactory() {
 func ExampleXMLDAOFactory() {
 	var factory interfaces.DAOFactory
 	factory = &factories.XMLDAOFactory{}
-	getMainAndDetail(factory)
+	_ = getMainAndDetail(context.Background(), factory,
+		interfaces.OrderMain{ID: "order-1"},
+		interfaces.OrderDetail{ID: "detail-1", OrderID: "order-1"})
 	// Output:
-	// xml main save
-	// xml detail save
+	// xml main save: order-1
+	// xml detail save: detail-1
 }
 
 // TestDynamicFactory 测试动态工厂选择
@@ -40,28 +55,22 @@ func ExampleXMLDAOFactory() {
 func TestDynamicFactory(t *testing.T) {
 	// 测试用例结构
 	tests := []struct {
-		name     string
-		factory  interfaces.DAOFactory
-		expected []string
+		name    string
+		factory interfaces.DAOFactory
 	}{
 		{
 			name:    "RDB Factory",
 			factory: &factories.RDBDAOFactory{},
-			expected: []string{
-				"rdb main save",
-				"rdb detail save",
-			},
 		},
 		{
 			name:    "XML Factory",
 			factory: &factories.XMLDAOFactory{},
-			expected: []string{
-				"xml main save",
-				"xml detail save",
-			},
 		},
 	}
 
+	main := interfaces.OrderMain{ID: "order-1"}
+	detail := interfaces.OrderDetail{ID: "detail-1", OrderID: "order-1"}
+
 	// 执行测试
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -73,8 +82,114 @@ func TestDynamicFactory(t *testing.T) {
 			if mainDAO == nil || detailDAO == nil {
 				t.Errorf("工厂创建的对象不能为nil")
 			}
-			mainDAO.SaveOrderMain()
-			detailDAO.SaveOrderDetail()
+			if err := mainDAO.SaveOrderMain(context.Background(), main); err != nil {
+				t.Errorf("SaveOrderMain失败: %v", err)
+			}
+			if err := detailDAO.SaveOrderDetail(context.Background(), detail); err != nil {
+				t.Errorf("SaveOrderDetail失败: %v", err)
+			}
+		})
+	}
+}
+
+// TestWithTxCommit 验证WithTx在fn成功返回时会把工作单元内的写入原子性地落盘
+func TestWithTxCommit(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "orders_main.xml")
+	detailPath := filepath.Join(dir, "orders_detail.xml")
+	factory := &factories.XMLDAOFactory{MainPath: mainPath, DetailPath: detailPath}
+	main := interfaces.OrderMain{ID: "order-1"}
+	detail := interfaces.OrderDetail{ID: "detail-1", OrderID: "order-1"}
+
+	err := WithTx(context.Background(), factory, func(tx interfaces.Tx) error {
+		if err := tx.CreateOrderMainDAO().SaveOrderMain(context.Background(), main); err != nil {
+			return err
+		}
+		return tx.CreateOrderDetailDAO().SaveOrderDetail(context.Background(), detail)
+	})
+	if err != nil {
+		t.Fatalf("WithTx commit失败: %v", err)
+	}
+
+	mainContent, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatalf("读取提交后的主记录文件失败: %v", err)
+	}
+	if !strings.Contains(string(mainContent), "<ID>order-1</ID>") {
+		t.Errorf("提交后的主记录文件内容缺少预期记录: %q", string(mainContent))
+	}
+
+	detailContent, err := os.ReadFile(detailPath)
+	if err != nil {
+		t.Fatalf("读取提交后的详情文件失败: %v", err)
+	}
+	if !strings.Contains(string(detailContent), "<ID>detail-1</ID>") {
+		t.Errorf("提交后的详情文件内容缺少预期记录: %q", string(detailContent))
+	}
+
+	mainDAO := factory.CreateOrderMainDAO()
+	got, err := mainDAO.GetByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("GetByID失败: %v", err)
+	}
+	if got.ID != main.ID {
+		t.Errorf("GetByID结果 = %+v, 期望ID为%q", got, main.ID)
+	}
+}
+
+// TestWithTxRollback 验证WithTx在fn返回error时回滚，不会有任何内容落盘
+func TestWithTxRollback(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "orders_main.xml")
+	detailPath := filepath.Join(dir, "orders_detail.xml")
+	factory := &factories.XMLDAOFactory{MainPath: mainPath, DetailPath: detailPath}
+
+	err := WithTx(context.Background(), factory, func(tx interfaces.Tx) error {
+		if err := tx.CreateOrderMainDAO().SaveOrderMain(context.Background(), interfaces.OrderMain{ID: "order-1"}); err != nil {
+			return err
+		}
+		return errFakeSaveDetail
+	})
+	if err != errFakeSaveDetail {
+		t.Fatalf("WithTx返回的error = %v, 期望 %v", err, errFakeSaveDetail)
+	}
+	if _, statErr := os.Stat(mainPath); !os.IsNotExist(statErr) {
+		t.Errorf("回滚后不应该生成主记录文件")
+	}
+	if _, statErr := os.Stat(detailPath); !os.IsNotExist(statErr) {
+		t.Errorf("回滚后不应该生成详情文件")
+	}
+}
+
+// TestWithTxPanicRollsBack 验证WithTx在fn发生panic时先回滚再把panic继续向上传播，
+// 不会有任何内容落盘
+func TestWithTxPanicRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "orders_main.xml")
+	detailPath := filepath.Join(dir, "orders_detail.xml")
+	factory := &factories.XMLDAOFactory{MainPath: mainPath, DetailPath: detailPath}
+
+	const panicValue = "boom"
+	func() {
+		defer func() {
+			got := recover()
+			if got != panicValue {
+				t.Fatalf("recover() = %v, 期望 %q", got, panicValue)
+			}
+		}()
+		_ = WithTx(context.Background(), factory, func(tx interfaces.Tx) error {
+			if err := tx.CreateOrderMainDAO().SaveOrderMain(context.Background(), interfaces.OrderMain{ID: "order-1"}); err != nil {
+				return err
+			}
+			panic(panicValue)
 		})
+		t.Fatal("期望WithTx在fn panic时继续向上传播panic")
+	}()
+
+	if _, statErr := os.Stat(mainPath); !os.IsNotExist(statErr) {
+		t.Errorf("panic回滚后不应该生成主记录文件")
+	}
+	if _, statErr := os.Stat(detailPath); !os.IsNotExist(statErr) {
+		t.Errorf("panic回滚后不应该生成详情文件")
 	}
 }
@@ -0,0 +1,38 @@
+package factories
+
+import (
+	"context"
+	"fmt"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+	"golang-design-pattern/05_abstract_factory/products"
+)
+
+// JSONDAOFactory 是持久化到换行分隔JSON(NDJSON)文件的DAO工厂实现
+// 实现了interfaces.DAOFactory接口，用于创建JSON文件存储相关的DAO对象族
+type JSONDAOFactory struct {
+	// MainPath/DetailPath 分别是订单主记录、详情记录落盘的NDJSON文件路径
+	MainPath   string
+	DetailPath string
+}
+
+// CreateOrderMainDAO 创建JSON存储的订单主记录DAO
+// 返回一个实现了OrderMainDAO接口的JSONMainDAO实例
+func (f *JSONDAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.JSONMainDAO{Path: f.MainPath}
+}
+
+// CreateOrderDetailDAO 创建JSON存储的订单详情记录DAO
+// 返回一个实现了OrderDetailDAO接口的JSONDetailDAO实例
+func (f *JSONDAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.JSONDetailDAO{Path: f.DetailPath}
+}
+
+// BeginTx JSONDAOFactory目前还不支持工作单元，直接返回error；
+// 需要事务保证的场景可以改用XMLDAOFactory的缓冲+原子rename方案
+func (f *JSONDAOFactory) BeginTx(ctx context.Context) (interfaces.Tx, error) {
+	return nil, fmt.Errorf("factories: JSONDAOFactory does not support transactions")
+}
+
+// Ensure JSONDAOFactory implements DAOFactory interface at compile time
+var _ interfaces.DAOFactory = (*JSONDAOFactory)(nil)
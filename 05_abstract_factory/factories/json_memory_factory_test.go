@@ -0,0 +1,85 @@
+package factories
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// TestJSONDAOFactoryRoundTrip 验证JSONDAOFactory能把订单主记录/详情记录
+// 以NDJSON格式落盘，并且可以通过GetByID/List查询回来
+func TestJSONDAOFactoryRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	factory := &JSONDAOFactory{
+		MainPath:   filepath.Join(dir, "order_main.ndjson"),
+		DetailPath: filepath.Join(dir, "order_detail.ndjson"),
+	}
+	ctx := context.Background()
+
+	mainDAO := factory.CreateOrderMainDAO()
+	detailDAO := factory.CreateOrderDetailDAO()
+
+	if err := mainDAO.SaveOrderMain(ctx, interfaces.OrderMain{ID: "order-1", CustomerID: "cust-1"}); err != nil {
+		t.Fatalf("SaveOrderMain失败: %v", err)
+	}
+	if err := detailDAO.SaveOrderDetail(ctx, interfaces.OrderDetail{ID: "detail-1", OrderID: "order-1", ItemName: "widget"}); err != nil {
+		t.Fatalf("SaveOrderDetail失败: %v", err)
+	}
+
+	got, err := mainDAO.GetByID(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("GetByID失败: %v", err)
+	}
+	if got.CustomerID != "cust-1" {
+		t.Errorf("GetByID结果 = %+v, 期望CustomerID为cust-1", got)
+	}
+
+	mains, err := mainDAO.List(ctx)
+	if err != nil {
+		t.Fatalf("List失败: %v", err)
+	}
+	if len(mains) != 1 {
+		t.Errorf("List返回%d条记录, 期望1条", len(mains))
+	}
+}
+
+// TestJSONDAOFactoryBeginTx 验证JSONDAOFactory目前如实地报告不支持事务
+func TestJSONDAOFactoryBeginTx(t *testing.T) {
+	factory := &JSONDAOFactory{}
+	if _, err := factory.BeginTx(context.Background()); err == nil {
+		t.Fatal("期望BeginTx返回error")
+	}
+}
+
+// TestMemoryDAOFactoryRoundTrip 验证MemoryDAOFactory创建出的DAO共享同一份底层存储
+func TestMemoryDAOFactoryRoundTrip(t *testing.T) {
+	factory := NewMemoryDAOFactory()
+	ctx := context.Background()
+
+	if err := factory.CreateOrderMainDAO().SaveOrderMain(ctx, interfaces.OrderMain{ID: "order-1"}); err != nil {
+		t.Fatalf("SaveOrderMain失败: %v", err)
+	}
+
+	// 重新CreateOrderMainDAO应该看到同一份存储里已有的记录
+	got, err := factory.CreateOrderMainDAO().GetByID(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("GetByID失败: %v", err)
+	}
+	if got.ID != "order-1" {
+		t.Errorf("GetByID结果 = %+v", got)
+	}
+}
+
+// TestMemoryDAOFactoryRollback 验证内存工厂如实地拒绝无法兑现的Rollback请求
+func TestMemoryDAOFactoryRollback(t *testing.T) {
+	factory := NewMemoryDAOFactory()
+	tx, err := factory.BeginTx(context.Background())
+	if err != nil {
+		t.Fatalf("BeginTx失败: %v", err)
+	}
+	if err := tx.Rollback(); err == nil {
+		t.Fatal("期望Rollback返回error")
+	}
+}
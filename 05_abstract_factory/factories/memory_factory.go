@@ -0,0 +1,72 @@
+package factories
+
+import (
+	"context"
+	"fmt"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+	"golang-design-pattern/05_abstract_factory/products"
+)
+
+// MemoryDAOFactory 是存储在进程内存中的DAO工厂实现
+// 同一个MemoryDAOFactory实例创建出的DAO共享同一份底层存储，主要用于测试场景
+type MemoryDAOFactory struct {
+	mainStore   *products.MemoryMainStore
+	detailStore *products.MemoryDetailStore
+}
+
+// NewMemoryDAOFactory 创建一个带有独立底层存储的MemoryDAOFactory
+func NewMemoryDAOFactory() *MemoryDAOFactory {
+	return &MemoryDAOFactory{
+		mainStore:   products.NewMemoryMainStore(),
+		detailStore: products.NewMemoryDetailStore(),
+	}
+}
+
+// CreateOrderMainDAO 创建共享本工厂底层存储的订单主记录DAO
+func (f *MemoryDAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.MemoryMainDAO{Store: f.mainStore}
+}
+
+// CreateOrderDetailDAO 创建共享本工厂底层存储的订单详情记录DAO
+func (f *MemoryDAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.MemoryDetailDAO{Store: f.detailStore}
+}
+
+// BeginTx 内存存储的写入本身就是进程内立即可见的，这里返回一个不做缓冲的工作单元
+func (f *MemoryDAOFactory) BeginTx(ctx context.Context) (interfaces.Tx, error) {
+	return &memoryTx{factory: f}, nil
+}
+
+// Ensure MemoryDAOFactory implements DAOFactory interface at compile time
+var _ interfaces.DAOFactory = (*MemoryDAOFactory)(nil)
+
+// memoryTx 是MemoryDAOFactory对应的工作单元实现
+// 底层存储没有隔离机制，写入一发生就立即可见，因此Commit是no-op，
+// Rollback也无法撤销已经发生的写入，只能诚实地返回error
+type memoryTx struct {
+	factory *MemoryDAOFactory
+}
+
+// CreateOrderMainDAO 创建与外层工厂共享存储的订单主记录DAO
+func (t *memoryTx) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return t.factory.CreateOrderMainDAO()
+}
+
+// CreateOrderDetailDAO 创建与外层工厂共享存储的订单详情记录DAO
+func (t *memoryTx) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return t.factory.CreateOrderDetailDAO()
+}
+
+// Commit 内存存储的写入已经立即生效，这里无需任何操作
+func (t *memoryTx) Commit() error {
+	return nil
+}
+
+// Rollback 内存存储无法撤销已经发生的写入
+func (t *memoryTx) Rollback() error {
+	return fmt.Errorf("factories: MemoryDAOFactory cannot roll back writes that already happened")
+}
+
+// Ensure memoryTx implements Tx interface at compile time
+var _ interfaces.Tx = (*memoryTx)(nil)
@@ -1,25 +1,78 @@
 package factories
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
 	"golang-design-pattern/05_abstract_factory/interfaces"
 	"golang-design-pattern/05_abstract_factory/products"
 )
 
 // RDBDAOFactory 关系型数据库的DAO工厂实现
 // 实现了interfaces.DAOFactory接口，用于创建关系型数据库相关的DAO对象族
-type RDBDAOFactory struct{}
+type RDBDAOFactory struct {
+	// DB 是BeginTx开启事务所依赖的数据库连接，零值工厂仍可用于非事务场景
+	DB *sql.DB
+}
+
+// NewRDBDAOFactory 使用给定的数据库连接创建RDBDAOFactory
+func NewRDBDAOFactory(db *sql.DB) *RDBDAOFactory {
+	return &RDBDAOFactory{DB: db}
+}
 
 // CreateOrderMainDAO 创建关系型数据库的订单主记录DAO
 // 返回一个实现了OrderMainDAO接口的RDBMainDAO实例
-func (*RDBDAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
-	return &products.RDBMainDAO{}
+func (f *RDBDAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.RDBMainDAO{DB: f.DB}
 }
 
 // CreateOrderDetailDAO 创建关系型数据库的订单详情记录DAO
 // 返回一个实现了OrderDetailDAO接口的RDBDetailDAO实例
-func (*RDBDAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
-	return &products.RDBDetailDAO{}
+func (f *RDBDAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.RDBDetailDAO{DB: f.DB}
+}
+
+// BeginTx 开启一个关系型数据库事务
+// 工作单元内创建的DAO都共享同一个*sql.Tx，Commit/Rollback直接委托给它
+func (f *RDBDAOFactory) BeginTx(ctx context.Context) (interfaces.Tx, error) {
+	if f.DB == nil {
+		return nil, fmt.Errorf("factories: RDBDAOFactory.DB is nil, cannot begin a transaction")
+	}
+	tx, err := f.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &rdbTx{tx: tx}, nil
 }
 
 // Ensure RDBDAOFactory implements DAOFactory interface at compile time
 var _ interfaces.DAOFactory = (*RDBDAOFactory)(nil)
+
+// rdbTx 是RDBDAOFactory对应的工作单元实现
+type rdbTx struct {
+	tx *sql.Tx
+}
+
+// CreateOrderMainDAO 创建与当前事务绑定的订单主记录DAO
+func (t *rdbTx) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.RDBMainDAO{Tx: t.tx}
+}
+
+// CreateOrderDetailDAO 创建与当前事务绑定的订单详情记录DAO
+func (t *rdbTx) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.RDBDetailDAO{Tx: t.tx}
+}
+
+// Commit 提交底层的*sql.Tx
+func (t *rdbTx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback 回滚底层的*sql.Tx
+func (t *rdbTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// Ensure rdbTx implements Tx interface at compile time
+var _ interfaces.Tx = (*rdbTx)(nil)
@@ -0,0 +1,14 @@
+package factories
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRDBDAOFactoryBeginTxNilDB 验证零值RDBDAOFactory的BeginTx返回error而不是panic
+func TestRDBDAOFactoryBeginTxNilDB(t *testing.T) {
+	f := &RDBDAOFactory{}
+	if _, err := f.BeginTx(context.Background()); err == nil {
+		t.Fatal("期望DB为nil时BeginTx返回error")
+	}
+}
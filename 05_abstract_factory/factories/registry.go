@@ -0,0 +1,151 @@
+// Package factories持有具体的DAOFactory实现。新增后端时，在
+// ../factorygen.json的backends列表里加一行，再执行go generate即可
+// 得到可用的工厂和DAO骨架，无需手写products/factories两份样板代码。
+//
+//go:generate go run ../../cmd/factorygen -config ../factorygen.json -out ..
+package factories
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// FactoryCtor 根据配置创建一个具体的DAOFactory实现
+type FactoryCtor func(cfg map[string]any) (interfaces.DAOFactory, error)
+
+// Registry 是DAOFactory实现的注册表
+// 允许第三方在不修改本包源码的前提下注册自己的产品族（如"redis"、"mongo"），
+// 解决了抽象工厂默认情况下对扩展封闭的问题
+type Registry struct {
+	mu    sync.RWMutex
+	ctors map[string]FactoryCtor
+}
+
+// NewRegistry 创建一个空的Registry
+func NewRegistry() *Registry {
+	return &Registry{ctors: make(map[string]FactoryCtor)}
+}
+
+// Register 以name注册一个FactoryCtor，重复的name会覆盖之前的注册
+func (r *Registry) Register(name string, ctor FactoryCtor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctors[name] = ctor
+}
+
+// Get 按name查找并调用对应的FactoryCtor来创建DAOFactory
+func (r *Registry) Get(name string, cfg map[string]any) (interfaces.DAOFactory, error) {
+	r.mu.RLock()
+	ctor, ok := r.ctors[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("factories: no DAOFactory registered for %q", name)
+	}
+	return ctor(cfg)
+}
+
+// DefaultRegistry 是内置rdb/xml/json/memory工厂注册的全局表，init()中完成注册
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.Register("rdb", func(cfg map[string]any) (interfaces.DAOFactory, error) {
+		return &RDBDAOFactory{}, nil
+	})
+	DefaultRegistry.Register("xml", func(cfg map[string]any) (interfaces.DAOFactory, error) {
+		mainPath, _ := cfg["main_path"].(string)
+		detailPath, _ := cfg["detail_path"].(string)
+		return &XMLDAOFactory{MainPath: mainPath, DetailPath: detailPath}, nil
+	})
+	DefaultRegistry.Register("json", func(cfg map[string]any) (interfaces.DAOFactory, error) {
+		mainPath, _ := cfg["main_path"].(string)
+		detailPath, _ := cfg["detail_path"].(string)
+		return &JSONDAOFactory{MainPath: mainPath, DetailPath: detailPath}, nil
+	})
+	DefaultRegistry.Register("memory", func(cfg map[string]any) (interfaces.DAOFactory, error) {
+		return NewMemoryDAOFactory(), nil
+	})
+}
+
+// factoryConfig 是NewFromConfig解析出的最小配置结构
+type factoryConfig struct {
+	Backend string         `json:"backend"`
+	Options map[string]any `json:"options"`
+}
+
+// NewFromConfig 从r中读取一段JSON（或`key: value`形式的简单YAML）配置，
+// 解析出backend名称与options后委托给DefaultRegistry创建对应的DAOFactory
+func NewFromConfig(r io.Reader) (interfaces.DAOFactory, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := parseFactoryConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Backend == "" {
+		return nil, fmt.Errorf("factories: config is missing \"backend\"")
+	}
+	return DefaultRegistry.Get(cfg.Backend, cfg.Options)
+}
+
+func parseFactoryConfig(data []byte) (factoryConfig, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		var cfg factoryConfig
+		if err := json.Unmarshal([]byte(trimmed), &cfg); err != nil {
+			return factoryConfig{}, err
+		}
+		return cfg, nil
+	}
+	return parseSimpleYAML(trimmed)
+}
+
+// parseSimpleYAML 解析形如下面的最小YAML子集，足以表达backend选择和它的options：
+//
+//	backend: xml
+//	options:
+//	  main_path: ./orders_main.xml
+//	  detail_path: ./orders_detail.xml
+//
+// 它不是一个通用的YAML解析器，只支持这种两层的key/value结构。
+func parseSimpleYAML(text string) (factoryConfig, error) {
+	cfg := factoryConfig{Options: map[string]any{}}
+	inOptions := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indented := line != strings.TrimLeft(line, " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if !indented {
+			inOptions = trimmed == "options:"
+			if inOptions {
+				continue
+			}
+		}
+
+		parts := strings.SplitN(trimmed, ":", 2)
+		if len(parts) != 2 {
+			return factoryConfig{}, fmt.Errorf("factories: cannot parse config line %q", line)
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		if indented && inOptions {
+			cfg.Options[key] = value
+			continue
+		}
+		if key == "backend" {
+			cfg.Backend = value
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,89 @@
+package factories
+
+import (
+	"strings"
+	"testing"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// TestDefaultRegistryBuiltins 验证rdb/xml/json/memory四个内置工厂已经在init()中注册好
+func TestDefaultRegistryBuiltins(t *testing.T) {
+	for _, name := range []string{"rdb", "xml", "json", "memory"} {
+		factory, err := DefaultRegistry.Get(name, nil)
+		if err != nil {
+			t.Fatalf("Get(%q) 失败: %v", name, err)
+		}
+		if factory == nil {
+			t.Errorf("Get(%q) 返回的DAOFactory不能为nil", name)
+		}
+	}
+}
+
+// TestRegistryUnknownName 验证查找未注册的名字会返回error而不是panic
+func TestRegistryUnknownName(t *testing.T) {
+	if _, err := DefaultRegistry.Get("mongo", nil); err == nil {
+		t.Fatal("期望查找未注册的后端返回error")
+	}
+}
+
+// TestRegistryCustomBackend 验证第三方可以在不修改本包的前提下注册新的产品族
+func TestRegistryCustomBackend(t *testing.T) {
+	r := NewRegistry()
+	var gotCfg map[string]any
+	r.Register("fake", func(cfg map[string]any) (interfaces.DAOFactory, error) {
+		gotCfg = cfg
+		return &XMLDAOFactory{}, nil
+	})
+
+	factory, err := r.Get("fake", map[string]any{"path": "./fake.xml"})
+	if err != nil {
+		t.Fatalf("Get(\"fake\") 失败: %v", err)
+	}
+	if factory == nil {
+		t.Fatal("自定义工厂不能为nil")
+	}
+	if gotCfg["path"] != "./fake.xml" {
+		t.Errorf("自定义工厂收到的cfg = %v", gotCfg)
+	}
+}
+
+// TestNewFromConfigJSON 验证NewFromConfig可以解析JSON格式的配置
+func TestNewFromConfigJSON(t *testing.T) {
+	r := strings.NewReader(`{"backend":"xml","options":{"main_path":"./orders_main.xml","detail_path":"./orders_detail.xml"}}`)
+	factory, err := NewFromConfig(r)
+	if err != nil {
+		t.Fatalf("NewFromConfig(JSON) 失败: %v", err)
+	}
+	xmlFactory, ok := factory.(*XMLDAOFactory)
+	if !ok {
+		t.Fatalf("期望得到*XMLDAOFactory，实际是 %T", factory)
+	}
+	if xmlFactory.MainPath != "./orders_main.xml" || xmlFactory.DetailPath != "./orders_detail.xml" {
+		t.Errorf("xmlFactory = %+v, 期望MainPath/DetailPath分别为./orders_main.xml和./orders_detail.xml", xmlFactory)
+	}
+}
+
+// TestNewFromConfigYAML 验证NewFromConfig可以解析简单的YAML子集配置
+func TestNewFromConfigYAML(t *testing.T) {
+	r := strings.NewReader("backend: xml\noptions:\n  main_path: ./orders_main.xml\n  detail_path: ./orders_detail.xml\n")
+	factory, err := NewFromConfig(r)
+	if err != nil {
+		t.Fatalf("NewFromConfig(YAML) 失败: %v", err)
+	}
+	xmlFactory, ok := factory.(*XMLDAOFactory)
+	if !ok {
+		t.Fatalf("期望得到*XMLDAOFactory，实际是 %T", factory)
+	}
+	if xmlFactory.MainPath != "./orders_main.xml" || xmlFactory.DetailPath != "./orders_detail.xml" {
+		t.Errorf("xmlFactory = %+v, 期望MainPath/DetailPath分别为./orders_main.xml和./orders_detail.xml", xmlFactory)
+	}
+}
+
+// TestNewFromConfigMissingBackend 验证缺少backend字段时返回error
+func TestNewFromConfigMissingBackend(t *testing.T) {
+	r := strings.NewReader(`{"options":{"path":"./orders.xml"}}`)
+	if _, err := NewFromConfig(r); err == nil {
+		t.Fatal("期望缺少backend字段时返回error")
+	}
+}
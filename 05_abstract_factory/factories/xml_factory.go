@@ -1,25 +1,116 @@
 package factories
 
 import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+
 	"golang-design-pattern/05_abstract_factory/interfaces"
 	"golang-design-pattern/05_abstract_factory/products"
 )
 
 // XMLDAOFactory XML存储的DAO工厂实现
 // 实现了interfaces.DAOFactory接口，用于创建XML文件存储相关的DAO对象族
-type XMLDAOFactory struct{}
+type XMLDAOFactory struct {
+	// MainPath/DetailPath 分别是订单主记录、详情记录落盘的XML文件路径，
+	// 两者必须分开，否则readXMLEntries按单一类型解码时会在两种记录混写的文件上出错
+	MainPath   string
+	DetailPath string
+}
 
 // CreateOrderMainDAO 创建XML存储的订单主记录DAO
 // 返回一个实现了OrderMainDAO接口的XMLMainDAO实例
-func (*XMLDAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
-	return &products.XMLMainDAO{}
+func (f *XMLDAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.XMLMainDAO{Path: f.MainPath}
 }
 
 // CreateOrderDetailDAO 创建XML存储的订单详情记录DAO
 // 返回一个实现了OrderDetailDAO接口的XMLDetailDAO实例
-func (*XMLDAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
-	return &products.XMLDetailDAO{}
+func (f *XMLDAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.XMLDetailDAO{Path: f.DetailPath}
+}
+
+// BeginTx 开启一个XML工作单元
+// 工作单元内创建的DAO只会把写入缓冲在内存中，直到Commit时才分别通过临时文件+rename
+// 原子性地落盘到各自的文件，Rollback则直接丢弃缓冲，文件内容不受影响
+func (f *XMLDAOFactory) BeginTx(ctx context.Context) (interfaces.Tx, error) {
+	return &xmlTx{mainPath: f.MainPath, detailPath: f.DetailPath}, nil
 }
 
 // Ensure XMLDAOFactory implements DAOFactory interface at compile time
 var _ interfaces.DAOFactory = (*XMLDAOFactory)(nil)
+
+// xmlTx 是XMLDAOFactory对应的工作单元实现
+// 订单主记录和详情记录的写入分别缓冲，Commit时各自落盘到对应的文件
+type xmlTx struct {
+	mu           sync.Mutex
+	mainPath     string
+	detailPath   string
+	mainBuffer   []string
+	detailBuffer []string
+}
+
+func (t *xmlTx) appendMain(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mainBuffer = append(t.mainBuffer, line)
+}
+
+func (t *xmlTx) appendDetail(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.detailBuffer = append(t.detailBuffer, line)
+}
+
+// CreateOrderMainDAO 创建写入当前工作单元主记录缓冲区的订单主记录DAO
+func (t *xmlTx) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.XMLMainDAO{OnSave: t.appendMain}
+}
+
+// CreateOrderDetailDAO 创建写入当前工作单元详情缓冲区的订单详情记录DAO
+func (t *xmlTx) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.XMLDetailDAO{OnSave: t.appendDetail}
+}
+
+// Commit 把两个缓冲区分别落盘到mainPath/detailPath
+func (t *xmlTx) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := commitXMLBuffer(t.mainPath, t.mainBuffer); err != nil {
+		return err
+	}
+	return commitXMLBuffer(t.detailPath, t.detailBuffer)
+}
+
+// commitXMLBuffer 把buffer的内容追加到path已有的内容之后，写入临时文件再rename
+// 覆盖目标文件，保证落盘要么是提交前的状态，要么是提交后的状态，不会出现半成品
+func commitXMLBuffer(path string, buffer []string) error {
+	if path == "" || len(buffer) == 0 {
+		return nil
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	content := string(existing) + strings.Join(buffer, "\n") + "\n"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Rollback 丢弃两个缓冲区的内容，由于Commit之前不会触碰目标文件，回滚无需做其他恢复动作
+func (t *xmlTx) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mainBuffer = nil
+	t.detailBuffer = nil
+	return nil
+}
+
+// Ensure xmlTx implements Tx interface at compile time
+var _ interfaces.Tx = (*xmlTx)(nil)
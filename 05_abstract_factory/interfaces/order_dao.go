@@ -1,19 +1,45 @@
 package interfaces
 
+import (
+	"context"
+	"time"
+)
+
+// OrderMain 是订单主记录
+type OrderMain struct {
+	ID         string
+	CustomerID string
+	TotalPrice float64
+	CreatedAt  time.Time
+}
+
+// OrderDetail 是订单详情记录
+type OrderDetail struct {
+	ID        string
+	OrderID   string
+	ItemName  string
+	Quantity  int
+	UnitPrice float64
+}
+
 // OrderMainDAO 为订单主记录
 // 负责订单主记录的持久化操作
-// 实现此接口的具体类型需要实现SaveOrderMain方法
-// 可以基于不同的存储技术实现（如RDB、XML、JSON等）
+// 实现此接口的具体类型需要实现SaveOrderMain/GetByID/List方法
+// 可以基于不同的存储技术实现（如RDB、XML、JSON、内存等）
 type OrderMainDAO interface {
-	SaveOrderMain()
+	SaveOrderMain(ctx context.Context, main OrderMain) error
+	GetByID(ctx context.Context, id string) (OrderMain, error)
+	List(ctx context.Context) ([]OrderMain, error)
 }
 
 // OrderDetailDAO 为订单详情记录
 // 负责订单详情记录的持久化操作
-// 实现此接口的具体类型需要实现SaveOrderDetail方法
+// 实现此接口的具体类型需要实现SaveOrderDetail/GetByID/List方法
 // 通常与OrderMainDAO配套使用，组成完整的产品族
 type OrderDetailDAO interface {
-	SaveOrderDetail()
+	SaveOrderDetail(ctx context.Context, detail OrderDetail) error
+	GetByID(ctx context.Context, id string) (OrderDetail, error)
+	List(ctx context.Context) ([]OrderDetail, error)
 }
 
 // DAOFactory DAO 抽象模式工厂接口
@@ -22,4 +48,22 @@ type OrderDetailDAO interface {
 type DAOFactory interface {
 	CreateOrderMainDAO() OrderMainDAO
 	CreateOrderDetailDAO() OrderDetailDAO
+
+	// BeginTx 开启一个工作单元（Unit of Work）
+	// 工作单元内创建的OrderMainDAO/OrderDetailDAO共享同一个事务性上下文，
+	// 只有在调用Tx.Commit后写入才会生效，否则可以通过Tx.Rollback整体撤销
+	BeginTx(ctx context.Context) (Tx, error)
+}
+
+// Tx 表示跨OrderMainDAO/OrderDetailDAO的一次工作单元
+// 由具体的DAOFactory实现开启，保证同一个工作单元内创建的DAO
+// 要么全部提交成功，要么全部回滚，不会出现主记录写入而详情记录丢失的情况
+type Tx interface {
+	CreateOrderMainDAO() OrderMainDAO
+	CreateOrderDetailDAO() OrderDetailDAO
+
+	// Commit 提交工作单元内的所有写入
+	Commit() error
+	// Rollback 撤销工作单元内的所有写入
+	Rollback() error
 }
@@ -0,0 +1,120 @@
+package products
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// readJSONLines 把path文件里按行排列的JSON对象解码为[]T，
+// 文件不存在时视为空列表而不是错误
+func readJSONLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []T
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		var entry T
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// JSONMainDAO 是OrderMainDAO的换行分隔JSON(NDJSON)文件实现
+// 实现了interfaces.OrderMainDAO接口，每条记录以一行JSON追加写入Path指向的文件
+type JSONMainDAO struct {
+	Path string
+}
+
+// SaveOrderMain 将订单主记录以一行JSON追加写入文件
+func (d *JSONMainDAO) SaveOrderMain(ctx context.Context, main interfaces.OrderMain) error {
+	raw, err := json.Marshal(main)
+	if err != nil {
+		return err
+	}
+	if err := appendLine(d.Path, string(raw)); err != nil {
+		return err
+	}
+	fmt.Printf("json main save: %s\n", main.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单主记录
+func (d *JSONMainDAO) GetByID(ctx context.Context, id string) (interfaces.OrderMain, error) {
+	all, err := d.List(ctx)
+	if err != nil {
+		return interfaces.OrderMain{}, err
+	}
+	for _, main := range all {
+		if main.ID == id {
+			return main, nil
+		}
+	}
+	return interfaces.OrderMain{}, fmt.Errorf("products: order main %q not found", id)
+}
+
+// List 返回NDJSON文件中的所有订单主记录
+func (d *JSONMainDAO) List(ctx context.Context) ([]interfaces.OrderMain, error) {
+	return readJSONLines[interfaces.OrderMain](d.Path)
+}
+
+// Ensure JSONMainDAO implements OrderMainDAO interface at compile time
+var _ interfaces.OrderMainDAO = (*JSONMainDAO)(nil)
+
+// JSONDetailDAO 是OrderDetailDAO的换行分隔JSON(NDJSON)文件实现，存储方式同JSONMainDAO
+type JSONDetailDAO struct {
+	Path string
+}
+
+// SaveOrderDetail 将订单详情记录以一行JSON追加写入文件
+func (d *JSONDetailDAO) SaveOrderDetail(ctx context.Context, detail interfaces.OrderDetail) error {
+	raw, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+	if err := appendLine(d.Path, string(raw)); err != nil {
+		return err
+	}
+	fmt.Printf("json detail save: %s\n", detail.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单详情记录
+func (d *JSONDetailDAO) GetByID(ctx context.Context, id string) (interfaces.OrderDetail, error) {
+	all, err := d.List(ctx)
+	if err != nil {
+		return interfaces.OrderDetail{}, err
+	}
+	for _, detail := range all {
+		if detail.ID == id {
+			return detail, nil
+		}
+	}
+	return interfaces.OrderDetail{}, fmt.Errorf("products: order detail %q not found", id)
+}
+
+// List 返回NDJSON文件中的所有订单详情记录
+func (d *JSONDetailDAO) List(ctx context.Context) ([]interfaces.OrderDetail, error) {
+	return readJSONLines[interfaces.OrderDetail](d.Path)
+}
+
+// Ensure JSONDetailDAO implements OrderDetailDAO interface at compile time
+var _ interfaces.OrderDetailDAO = (*JSONDetailDAO)(nil)
@@ -0,0 +1,109 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// MemoryMainStore 是MemoryMainDAO的底层存储，一个Store可以被多个
+// MemoryMainDAO实例共享，使它们看到同一份数据
+type MemoryMainStore struct {
+	mu      sync.RWMutex
+	records []interfaces.OrderMain
+}
+
+// NewMemoryMainStore 创建一个空的MemoryMainStore
+func NewMemoryMainStore() *MemoryMainStore {
+	return &MemoryMainStore{}
+}
+
+// MemoryMainDAO 是OrderMainDAO的内存实现
+// 记录保存在进程内的slice中，不做任何持久化，主要用于测试场景
+type MemoryMainDAO struct {
+	Store *MemoryMainStore
+}
+
+// SaveOrderMain 把订单主记录追加到Store的slice中
+func (d *MemoryMainDAO) SaveOrderMain(ctx context.Context, main interfaces.OrderMain) error {
+	d.Store.mu.Lock()
+	defer d.Store.mu.Unlock()
+	d.Store.records = append(d.Store.records, main)
+	return nil
+}
+
+// GetByID 按ID查询订单主记录
+func (d *MemoryMainDAO) GetByID(ctx context.Context, id string) (interfaces.OrderMain, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	for _, main := range d.Store.records {
+		if main.ID == id {
+			return main, nil
+		}
+	}
+	return interfaces.OrderMain{}, fmt.Errorf("products: order main %q not found", id)
+}
+
+// List 返回Store中保存的所有订单主记录
+func (d *MemoryMainDAO) List(ctx context.Context) ([]interfaces.OrderMain, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	result := make([]interfaces.OrderMain, len(d.Store.records))
+	copy(result, d.Store.records)
+	return result, nil
+}
+
+// Ensure MemoryMainDAO implements OrderMainDAO interface at compile time
+var _ interfaces.OrderMainDAO = (*MemoryMainDAO)(nil)
+
+// MemoryDetailStore 是MemoryDetailDAO的底层存储，一个Store可以被多个
+// MemoryDetailDAO实例共享，使它们看到同一份数据
+type MemoryDetailStore struct {
+	mu      sync.RWMutex
+	records []interfaces.OrderDetail
+}
+
+// NewMemoryDetailStore 创建一个空的MemoryDetailStore
+func NewMemoryDetailStore() *MemoryDetailStore {
+	return &MemoryDetailStore{}
+}
+
+// MemoryDetailDAO 是OrderDetailDAO的内存实现
+// 记录保存在进程内的slice中，不做任何持久化，主要用于测试场景
+type MemoryDetailDAO struct {
+	Store *MemoryDetailStore
+}
+
+// SaveOrderDetail 把订单详情记录追加到Store的slice中
+func (d *MemoryDetailDAO) SaveOrderDetail(ctx context.Context, detail interfaces.OrderDetail) error {
+	d.Store.mu.Lock()
+	defer d.Store.mu.Unlock()
+	d.Store.records = append(d.Store.records, detail)
+	return nil
+}
+
+// GetByID 按ID查询订单详情记录
+func (d *MemoryDetailDAO) GetByID(ctx context.Context, id string) (interfaces.OrderDetail, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	for _, detail := range d.Store.records {
+		if detail.ID == id {
+			return detail, nil
+		}
+	}
+	return interfaces.OrderDetail{}, fmt.Errorf("products: order detail %q not found", id)
+}
+
+// List 返回Store中保存的所有订单详情记录
+func (d *MemoryDetailDAO) List(ctx context.Context) ([]interfaces.OrderDetail, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	result := make([]interfaces.OrderDetail, len(d.Store.records))
+	copy(result, d.Store.records)
+	return result, nil
+}
+
+// Ensure MemoryDetailDAO implements OrderDetailDAO interface at compile time
+var _ interfaces.OrderDetailDAO = (*MemoryDetailDAO)(nil)
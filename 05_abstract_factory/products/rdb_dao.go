@@ -1,18 +1,88 @@
 package products
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+
 	"golang-design-pattern/05_abstract_factory/interfaces"
 )
 
+// sqlExecer 是*sql.DB和*sql.Tx共有的子集，RDB系DAO只依赖这个子集，
+// 这样同一个DAO既能跑在普通连接上，也能跑在BeginTx开启的事务里
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
 // RDBMainDAO 关系型数据库的OrderMainDAO实现
 // 实现了interfaces.OrderMainDAO接口，用于关系型数据库中订单主记录的存储
-type RDBMainDAO struct{}
+type RDBMainDAO struct {
+	DB *sql.DB
+	// Tx 非nil时表示该DAO运行在某个工作单元内，写入/查询都会使用这个事务
+	Tx *sql.Tx
+}
+
+func (d *RDBMainDAO) execer() sqlExecer {
+	if d.Tx != nil {
+		return d.Tx
+	}
+	return d.DB
+}
 
 // SaveOrderMain 将订单主记录保存到关系型数据库
-// 实际应用中这里会包含具体的数据库操作逻辑
-func (*RDBMainDAO) SaveOrderMain() {
-	fmt.Print("rdb main save\n")
+// 没有配置DB/Tx时退化为打印，便于脱离真实数据库直接跑示例
+func (d *RDBMainDAO) SaveOrderMain(ctx context.Context, main interfaces.OrderMain) error {
+	if d.DB == nil && d.Tx == nil {
+		fmt.Printf("rdb main save: %s\n", main.ID)
+		return nil
+	}
+	_, err := d.execer().ExecContext(ctx,
+		`INSERT INTO order_main (id, customer_id, total_price, created_at) VALUES (?, ?, ?, ?)`,
+		main.ID, main.CustomerID, main.TotalPrice, main.CreatedAt)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("rdb main save: %s\n", main.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单主记录
+func (d *RDBMainDAO) GetByID(ctx context.Context, id string) (interfaces.OrderMain, error) {
+	if d.DB == nil && d.Tx == nil {
+		return interfaces.OrderMain{}, fmt.Errorf("products: order main %q not found", id)
+	}
+	row := d.execer().QueryRowContext(ctx,
+		`SELECT id, customer_id, total_price, created_at FROM order_main WHERE id = ?`, id)
+	var main interfaces.OrderMain
+	if err := row.Scan(&main.ID, &main.CustomerID, &main.TotalPrice, &main.CreatedAt); err != nil {
+		return interfaces.OrderMain{}, err
+	}
+	return main, nil
+}
+
+// List 返回关系型数据库中的所有订单主记录
+func (d *RDBMainDAO) List(ctx context.Context) ([]interfaces.OrderMain, error) {
+	if d.DB == nil && d.Tx == nil {
+		return nil, nil
+	}
+	rows, err := d.execer().QueryContext(ctx,
+		`SELECT id, customer_id, total_price, created_at FROM order_main`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []interfaces.OrderMain
+	for rows.Next() {
+		var main interfaces.OrderMain
+		if err := rows.Scan(&main.ID, &main.CustomerID, &main.TotalPrice, &main.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, main)
+	}
+	return result, rows.Err()
 }
 
 // Ensure RDBMainDAO implements OrderMainDAO interface at compile time
@@ -20,12 +90,71 @@ var _ interfaces.OrderMainDAO = (*RDBMainDAO)(nil)
 
 // RDBDetailDAO 为关系型数据库的OrderDetailDAO实现
 // 实现了interfaces.OrderDetailDAO接口，用于关系型数据库中订单详情记录的存储
-type RDBDetailDAO struct{}
+type RDBDetailDAO struct {
+	DB *sql.DB
+	// Tx 非nil时表示该DAO运行在某个工作单元内，写入/查询都会使用这个事务
+	Tx *sql.Tx
+}
+
+func (d *RDBDetailDAO) execer() sqlExecer {
+	if d.Tx != nil {
+		return d.Tx
+	}
+	return d.DB
+}
 
 // SaveOrderDetail 将订单详情记录保存到关系型数据库
-// 实际应用中这里会包含具体的数据库操作逻辑
-func (*RDBDetailDAO) SaveOrderDetail() {
-	fmt.Print("rdb detail save\n")
+// 没有配置DB/Tx时退化为打印，便于脱离真实数据库直接跑示例
+func (d *RDBDetailDAO) SaveOrderDetail(ctx context.Context, detail interfaces.OrderDetail) error {
+	if d.DB == nil && d.Tx == nil {
+		fmt.Printf("rdb detail save: %s\n", detail.ID)
+		return nil
+	}
+	_, err := d.execer().ExecContext(ctx,
+		`INSERT INTO order_detail (id, order_id, item_name, quantity, unit_price) VALUES (?, ?, ?, ?, ?)`,
+		detail.ID, detail.OrderID, detail.ItemName, detail.Quantity, detail.UnitPrice)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("rdb detail save: %s\n", detail.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单详情记录
+func (d *RDBDetailDAO) GetByID(ctx context.Context, id string) (interfaces.OrderDetail, error) {
+	if d.DB == nil && d.Tx == nil {
+		return interfaces.OrderDetail{}, fmt.Errorf("products: order detail %q not found", id)
+	}
+	row := d.execer().QueryRowContext(ctx,
+		`SELECT id, order_id, item_name, quantity, unit_price FROM order_detail WHERE id = ?`, id)
+	var detail interfaces.OrderDetail
+	if err := row.Scan(&detail.ID, &detail.OrderID, &detail.ItemName, &detail.Quantity, &detail.UnitPrice); err != nil {
+		return interfaces.OrderDetail{}, err
+	}
+	return detail, nil
+}
+
+// List 返回关系型数据库中的所有订单详情记录
+func (d *RDBDetailDAO) List(ctx context.Context) ([]interfaces.OrderDetail, error) {
+	if d.DB == nil && d.Tx == nil {
+		return nil, nil
+	}
+	rows, err := d.execer().QueryContext(ctx,
+		`SELECT id, order_id, item_name, quantity, unit_price FROM order_detail`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []interfaces.OrderDetail
+	for rows.Next() {
+		var detail interfaces.OrderDetail
+		if err := rows.Scan(&detail.ID, &detail.OrderID, &detail.ItemName, &detail.Quantity, &detail.UnitPrice); err != nil {
+			return nil, err
+		}
+		result = append(result, detail)
+	}
+	return result, rows.Err()
 }
 
 // Ensure RDBDetailDAO implements OrderDetailDAO interface at compile time
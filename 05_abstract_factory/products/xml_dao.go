@@ -1,31 +1,220 @@
 package products
 
 import (
+	"bytes"
+	"context"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"os"
+	"time"
+
 	"golang-design-pattern/05_abstract_factory/interfaces"
 )
 
+// timeFromUnix 把Unix秒还原为time.Time，0表示没有记录时间戳
+func timeFromUnix(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
+
+// appendLine 把line追加写入path指向的文件，不存在则创建
+func appendLine(path, line string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(line + "\n")
+	return err
+}
+
+// readXMLEntries 把path文件里依次排列的XML片段解码为[]T，
+// 文件不存在时视为空列表而不是错误
+func readXMLEntries[T any](path string) ([]T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var entries []T
+	for {
+		var entry T
+		if err := decoder.Decode(&entry); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// xmlOrderMain 是OrderMain在XML文件里的落盘格式
+type xmlOrderMain struct {
+	XMLName    xml.Name `xml:"OrderMain"`
+	ID         string   `xml:"ID"`
+	CustomerID string   `xml:"CustomerID"`
+	TotalPrice float64  `xml:"TotalPrice"`
+	CreatedAt  int64    `xml:"CreatedAt"`
+}
+
 // XMLMainDAO XML存储的OrderMainDAO实现
 // 实现了interfaces.OrderMainDAO接口，用于XML文件中订单主记录的存储
-type XMLMainDAO struct{}
+type XMLMainDAO struct {
+	// Path 是非事务场景下直接读写的XML文件路径
+	Path string
+	// OnSave 非nil时表示该DAO运行在某个工作单元内，写入会缓冲到工作单元而不是直接落盘
+	OnSave func(line string)
+}
 
 // SaveOrderMain 将订单主记录保存到XML文件
-// 实际应用中这里会包含具体的XML文件操作逻辑
-func (*XMLMainDAO) SaveOrderMain() {
-	fmt.Print("xml main save\n")
+func (d *XMLMainDAO) SaveOrderMain(ctx context.Context, main interfaces.OrderMain) error {
+	raw, err := xml.Marshal(xmlOrderMain{
+		ID:         main.ID,
+		CustomerID: main.CustomerID,
+		TotalPrice: main.TotalPrice,
+		CreatedAt:  main.CreatedAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if d.OnSave != nil {
+		d.OnSave(string(raw))
+	} else if d.Path != "" {
+		if err := appendLine(d.Path, string(raw)); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("xml main save: %s\n", main.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单主记录
+func (d *XMLMainDAO) GetByID(ctx context.Context, id string) (interfaces.OrderMain, error) {
+	all, err := d.List(ctx)
+	if err != nil {
+		return interfaces.OrderMain{}, err
+	}
+	for _, main := range all {
+		if main.ID == id {
+			return main, nil
+		}
+	}
+	return interfaces.OrderMain{}, fmt.Errorf("products: order main %q not found", id)
+}
+
+// List 返回XML文件中的所有订单主记录
+func (d *XMLMainDAO) List(ctx context.Context) ([]interfaces.OrderMain, error) {
+	if d.Path == "" {
+		return nil, nil
+	}
+	entries, err := readXMLEntries[xmlOrderMain](d.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interfaces.OrderMain, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, interfaces.OrderMain{
+			ID:         e.ID,
+			CustomerID: e.CustomerID,
+			TotalPrice: e.TotalPrice,
+			CreatedAt:  timeFromUnix(e.CreatedAt),
+		})
+	}
+	return result, nil
 }
 
 // Ensure XMLMainDAO implements OrderMainDAO interface at compile time
 var _ interfaces.OrderMainDAO = (*XMLMainDAO)(nil)
 
+// xmlOrderDetail 是OrderDetail在XML文件里的落盘格式
+type xmlOrderDetail struct {
+	XMLName   xml.Name `xml:"OrderDetail"`
+	ID        string   `xml:"ID"`
+	OrderID   string   `xml:"OrderID"`
+	ItemName  string   `xml:"ItemName"`
+	Quantity  int      `xml:"Quantity"`
+	UnitPrice float64  `xml:"UnitPrice"`
+}
+
 // XMLDetailDAO XML存储的OrderDetailDAO实现
 // 实现了interfaces.OrderDetailDAO接口，用于XML文件中订单详情记录的存储
-type XMLDetailDAO struct{}
+type XMLDetailDAO struct {
+	// Path 是非事务场景下直接读写的XML文件路径
+	Path string
+	// OnSave 非nil时表示该DAO运行在某个工作单元内，写入会缓冲到工作单元而不是直接落盘
+	OnSave func(line string)
+}
 
 // SaveOrderDetail 将订单详情记录保存到XML文件
-// 实际应用中这里会包含具体的XML文件操作逻辑
-func (*XMLDetailDAO) SaveOrderDetail() {
-	fmt.Print("xml detail save")
+func (d *XMLDetailDAO) SaveOrderDetail(ctx context.Context, detail interfaces.OrderDetail) error {
+	raw, err := xml.Marshal(xmlOrderDetail{
+		ID:        detail.ID,
+		OrderID:   detail.OrderID,
+		ItemName:  detail.ItemName,
+		Quantity:  detail.Quantity,
+		UnitPrice: detail.UnitPrice,
+	})
+	if err != nil {
+		return err
+	}
+
+	if d.OnSave != nil {
+		d.OnSave(string(raw))
+	} else if d.Path != "" {
+		if err := appendLine(d.Path, string(raw)); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("xml detail save: %s", detail.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单详情记录
+func (d *XMLDetailDAO) GetByID(ctx context.Context, id string) (interfaces.OrderDetail, error) {
+	all, err := d.List(ctx)
+	if err != nil {
+		return interfaces.OrderDetail{}, err
+	}
+	for _, detail := range all {
+		if detail.ID == id {
+			return detail, nil
+		}
+	}
+	return interfaces.OrderDetail{}, fmt.Errorf("products: order detail %q not found", id)
+}
+
+// List 返回XML文件中的所有订单详情记录
+func (d *XMLDetailDAO) List(ctx context.Context) ([]interfaces.OrderDetail, error) {
+	if d.Path == "" {
+		return nil, nil
+	}
+	entries, err := readXMLEntries[xmlOrderDetail](d.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interfaces.OrderDetail, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, interfaces.OrderDetail{
+			ID:        e.ID,
+			OrderID:   e.OrderID,
+			ItemName:  e.ItemName,
+			Quantity:  e.Quantity,
+			UnitPrice: e.UnitPrice,
+		})
+	}
+	return result, nil
 }
 
 // Ensure XMLDetailDAO implements OrderDetailDAO interface at compile time
@@ -0,0 +1,31 @@
+package abstractfactory
+
+import (
+	"context"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// WithTx 在factory开启的一个工作单元内执行fn
+// fn返回error或发生panic时自动回滚，否则提交；
+// panic会在回滚之后继续向上传播，方便调用方感知真正的失败原因
+func WithTx(ctx context.Context, factory interfaces.DAOFactory, fn func(interfaces.Tx) error) (err error) {
+	tx, err := factory.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return rbErr
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,265 @@
+// Command factorygen scaffolds the boilerplate for a new abstract factory
+// backend under 05_abstract_factory: the product DAOs, the DAOFactory
+// implementation, and its registration with factories.DefaultRegistry.
+//
+// It reads a small DSL file listing the backend names that should exist
+// (see 05_abstract_factory/factorygen.json) and, for every backend that
+// doesn't already have a hand-written or previously generated factory,
+// emits products/<backend>_dao.go and factories/<backend>_factory.go.
+// Adding a new backend is then a single line in the DSL file followed by
+// `go generate ./...`.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// dsl 是factorygen.json解析出的配置
+type dsl struct {
+	// Backends 是所有应该存在的后端名字，小写、不含空格
+	Backends []string `json:"backends"`
+}
+
+func main() {
+	configPath := flag.String("config", "factorygen.json", "factorygen DSL文件路径")
+	outDir := flag.String("out", ".", "05_abstract_factory目录路径")
+	flag.Parse()
+
+	if err := run(*configPath, *outDir); err != nil {
+		fmt.Fprintln(os.Stderr, "factorygen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath, outDir string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	var cfg dsl
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parse %s: %w", configPath, err)
+	}
+
+	for _, backend := range cfg.Backends {
+		if err := generateBackend(outDir, backend); err != nil {
+			return fmt.Errorf("generate backend %q: %w", backend, err)
+		}
+	}
+	return nil
+}
+
+// generateBackend为backend生成products/<backend>_dao.go和
+// factories/<backend>_factory.go，已经存在的后端保持不变，跳过生成
+func generateBackend(outDir, backend string) error {
+	factoryPath := filepath.Join(outDir, "factories", backend+"_factory.go")
+	if _, err := os.Stat(factoryPath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	productPath := filepath.Join(outDir, "products", backend+"_dao.go")
+	data := struct {
+		Name  string
+		Lower string
+	}{
+		Name:  exportedName(backend),
+		Lower: strings.ToLower(backend),
+	}
+
+	if err := renderFile(productPath, productTemplate, data); err != nil {
+		return err
+	}
+	return renderFile(factoryPath, factoryTemplate, data)
+}
+
+// exportedName把backend名字的首字母大写，用作导出的类型名前缀
+func exportedName(backend string) string {
+	if backend == "" {
+		return backend
+	}
+	return strings.ToUpper(backend[:1]) + backend[1:]
+}
+
+func renderFile(path, tmplText string, data any) error {
+	tmpl := template.Must(template.New(filepath.Base(path)).Parse(tmplText))
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return tmpl.Execute(f, data)
+}
+
+const productTemplate = `// Code generated by factorygen from factorygen.json; DO NOT EDIT.
+
+package products
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+)
+
+// {{.Name}}MainStore 是{{.Name}}MainDAO的底层存储占位实现
+// 接入真正的{{.Name}}客户端时，把这里换成真实的读写逻辑
+type {{.Name}}MainStore struct {
+	mu      sync.RWMutex
+	records []interfaces.OrderMain
+}
+
+// New{{.Name}}MainStore 创建一个空的{{.Name}}MainStore
+func New{{.Name}}MainStore() *{{.Name}}MainStore {
+	return &{{.Name}}MainStore{}
+}
+
+// {{.Name}}MainDAO 是OrderMainDAO的{{.Name}}占位实现
+type {{.Name}}MainDAO struct {
+	Store *{{.Name}}MainStore
+}
+
+// SaveOrderMain 把订单主记录追加到Store的slice中
+func (d *{{.Name}}MainDAO) SaveOrderMain(ctx context.Context, main interfaces.OrderMain) error {
+	d.Store.mu.Lock()
+	defer d.Store.mu.Unlock()
+	d.Store.records = append(d.Store.records, main)
+	fmt.Printf("{{.Lower}} main save: %s\n", main.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单主记录
+func (d *{{.Name}}MainDAO) GetByID(ctx context.Context, id string) (interfaces.OrderMain, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	for _, main := range d.Store.records {
+		if main.ID == id {
+			return main, nil
+		}
+	}
+	return interfaces.OrderMain{}, fmt.Errorf("products: order main %q not found", id)
+}
+
+// List 返回Store中保存的所有订单主记录
+func (d *{{.Name}}MainDAO) List(ctx context.Context) ([]interfaces.OrderMain, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	result := make([]interfaces.OrderMain, len(d.Store.records))
+	copy(result, d.Store.records)
+	return result, nil
+}
+
+// Ensure {{.Name}}MainDAO implements OrderMainDAO interface at compile time
+var _ interfaces.OrderMainDAO = (*{{.Name}}MainDAO)(nil)
+
+// {{.Name}}DetailStore 是{{.Name}}DetailDAO的底层存储占位实现
+type {{.Name}}DetailStore struct {
+	mu      sync.RWMutex
+	records []interfaces.OrderDetail
+}
+
+// New{{.Name}}DetailStore 创建一个空的{{.Name}}DetailStore
+func New{{.Name}}DetailStore() *{{.Name}}DetailStore {
+	return &{{.Name}}DetailStore{}
+}
+
+// {{.Name}}DetailDAO 是OrderDetailDAO的{{.Name}}占位实现
+type {{.Name}}DetailDAO struct {
+	Store *{{.Name}}DetailStore
+}
+
+// SaveOrderDetail 把订单详情记录追加到Store的slice中
+func (d *{{.Name}}DetailDAO) SaveOrderDetail(ctx context.Context, detail interfaces.OrderDetail) error {
+	d.Store.mu.Lock()
+	defer d.Store.mu.Unlock()
+	d.Store.records = append(d.Store.records, detail)
+	fmt.Printf("{{.Lower}} detail save: %s\n", detail.ID)
+	return nil
+}
+
+// GetByID 按ID查询订单详情记录
+func (d *{{.Name}}DetailDAO) GetByID(ctx context.Context, id string) (interfaces.OrderDetail, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	for _, detail := range d.Store.records {
+		if detail.ID == id {
+			return detail, nil
+		}
+	}
+	return interfaces.OrderDetail{}, fmt.Errorf("products: order detail %q not found", id)
+}
+
+// List 返回Store中保存的所有订单详情记录
+func (d *{{.Name}}DetailDAO) List(ctx context.Context) ([]interfaces.OrderDetail, error) {
+	d.Store.mu.RLock()
+	defer d.Store.mu.RUnlock()
+	result := make([]interfaces.OrderDetail, len(d.Store.records))
+	copy(result, d.Store.records)
+	return result, nil
+}
+
+// Ensure {{.Name}}DetailDAO implements OrderDetailDAO interface at compile time
+var _ interfaces.OrderDetailDAO = (*{{.Name}}DetailDAO)(nil)
+`
+
+const factoryTemplate = `// Code generated by factorygen from factorygen.json; DO NOT EDIT.
+
+package factories
+
+import (
+	"context"
+	"fmt"
+
+	"golang-design-pattern/05_abstract_factory/interfaces"
+	"golang-design-pattern/05_abstract_factory/products"
+)
+
+// {{.Name}}DAOFactory 是factorygen生成的占位工厂实现
+// 接入真正的{{.Name}}客户端后，把CreateOrderMainDAO/CreateOrderDetailDAO
+// 换成真实的连接逻辑即可
+type {{.Name}}DAOFactory struct {
+	mainStore   *products.{{.Name}}MainStore
+	detailStore *products.{{.Name}}DetailStore
+}
+
+// New{{.Name}}DAOFactory 创建一个带有独立底层存储的{{.Name}}DAOFactory
+func New{{.Name}}DAOFactory() *{{.Name}}DAOFactory {
+	return &{{.Name}}DAOFactory{
+		mainStore:   products.New{{.Name}}MainStore(),
+		detailStore: products.New{{.Name}}DetailStore(),
+	}
+}
+
+// CreateOrderMainDAO 创建共享本工厂底层存储的订单主记录DAO
+func (f *{{.Name}}DAOFactory) CreateOrderMainDAO() interfaces.OrderMainDAO {
+	return &products.{{.Name}}MainDAO{Store: f.mainStore}
+}
+
+// CreateOrderDetailDAO 创建共享本工厂底层存储的订单详情记录DAO
+func (f *{{.Name}}DAOFactory) CreateOrderDetailDAO() interfaces.OrderDetailDAO {
+	return &products.{{.Name}}DetailDAO{Store: f.detailStore}
+}
+
+// BeginTx 占位实现尚不支持工作单元，接入真实客户端时据其事务能力实现
+func (f *{{.Name}}DAOFactory) BeginTx(ctx context.Context) (interfaces.Tx, error) {
+	return nil, fmt.Errorf("factories: {{.Name}}DAOFactory does not support transactions yet")
+}
+
+// Ensure {{.Name}}DAOFactory implements DAOFactory interface at compile time
+var _ interfaces.DAOFactory = (*{{.Name}}DAOFactory)(nil)
+
+func init() {
+	DefaultRegistry.Register("{{.Lower}}", func(cfg map[string]any) (interfaces.DAOFactory, error) {
+		return New{{.Name}}DAOFactory(), nil
+	})
+}
+`
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunGeneratesNewBackend 验证run()会为DSL里列出的新后端生成product/factory文件
+func TestRunGeneratesNewBackend(t *testing.T) {
+	outDir := t.TempDir()
+	for _, sub := range []string{"products", "factories"} {
+		if err := os.MkdirAll(filepath.Join(outDir, sub), 0o755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+	}
+
+	configPath := filepath.Join(outDir, "factorygen.json")
+	if err := os.WriteFile(configPath, []byte(`{"backends":["mongo"]}`), 0o644); err != nil {
+		t.Fatalf("写入config失败: %v", err)
+	}
+
+	if err := run(configPath, outDir); err != nil {
+		t.Fatalf("run() 失败: %v", err)
+	}
+
+	product, err := os.ReadFile(filepath.Join(outDir, "products", "mongo_dao.go"))
+	if err != nil {
+		t.Fatalf("读取生成的product文件失败: %v", err)
+	}
+	if !strings.Contains(string(product), "type MongoMainDAO struct") {
+		t.Errorf("生成的product文件缺少MongoMainDAO定义")
+	}
+
+	factory, err := os.ReadFile(filepath.Join(outDir, "factories", "mongo_factory.go"))
+	if err != nil {
+		t.Fatalf("读取生成的factory文件失败: %v", err)
+	}
+	if !strings.Contains(string(factory), `DefaultRegistry.Register("mongo"`) {
+		t.Errorf("生成的factory文件没有把mongo注册进DefaultRegistry")
+	}
+}
+
+// TestRunSkipsExistingBackend 验证run()不会覆盖已经存在的factory文件
+func TestRunSkipsExistingBackend(t *testing.T) {
+	outDir := t.TempDir()
+	for _, sub := range []string{"products", "factories"} {
+		if err := os.MkdirAll(filepath.Join(outDir, sub), 0o755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+	}
+
+	factoryPath := filepath.Join(outDir, "factories", "rdb_factory.go")
+	want := "// hand-written, do not regenerate\n"
+	if err := os.WriteFile(factoryPath, []byte(want), 0o644); err != nil {
+		t.Fatalf("写入既有factory文件失败: %v", err)
+	}
+
+	configPath := filepath.Join(outDir, "factorygen.json")
+	if err := os.WriteFile(configPath, []byte(`{"backends":["rdb"]}`), 0o644); err != nil {
+		t.Fatalf("写入config失败: %v", err)
+	}
+
+	if err := run(configPath, outDir); err != nil {
+		t.Fatalf("run() 失败: %v", err)
+	}
+
+	got, err := os.ReadFile(factoryPath)
+	if err != nil {
+		t.Fatalf("读取factory文件失败: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("已存在的factory文件被覆盖: %q", string(got))
+	}
+}